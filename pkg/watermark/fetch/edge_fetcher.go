@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,7 +20,10 @@ type edgeFetcher struct {
 	ctx              context.Context
 	edgeName         string
 	processorManager *ProcessorManager
-	log              *zap.SugaredLogger
+	// inProgress tracks the offsets this vertex currently has checked out for processing, so
+	// GetOutputWatermark can clamp to the oldest one still in flight.
+	inProgress *offsetTracker
+	log        *zap.SugaredLogger
 }
 
 // NewEdgeFetcher returns a new edge fetcher, processorManager has the details about the processors responsible for writing to this
@@ -29,6 +33,7 @@ func NewEdgeFetcher(ctx context.Context, edgeName string, processorManager *Proc
 		ctx:              ctx,
 		edgeName:         edgeName,
 		processorManager: processorManager,
+		inProgress:       newOffsetTracker(),
 		log:              logging.FromContext(ctx).With("edgeName", edgeName),
 	}
 }
@@ -59,7 +64,7 @@ func (e *edgeFetcher) GetHeadWatermark() processor.Watermark {
 		// Use -1 as default watermark value to indicate there is no valid watermark yet.
 		return processor.Watermark(time.Unix(-1, 0))
 	}
-	return processor.Watermark(time.Unix(epoch, 0))
+	return e.applyHolds(processor.Watermark(time.Unix(epoch, 0)))
 }
 
 // GetWatermark gets the smallest timestamp for the given offset
@@ -89,5 +94,159 @@ func (e *edgeFetcher) GetWatermark(inputOffset isb.Offset) processor.Watermark {
 	}
 	e.log.Debugf("%s[%s] get watermark for offset %d: %+v", debugString.String(), e.edgeName, offset, epoch)
 
-	return processor.Watermark(time.Unix(epoch, 0))
+	return e.applyHolds(processor.Watermark(time.Unix(epoch, 0)))
+}
+
+// GetWatermarks gets the smallest timestamp for each of the given offsets in a single pass over
+// the processor set, instead of paying the full GetWatermark cost once per offset. The offsets
+// are sorted once up front, and each processor's offsetTimeline -- itself offset-sorted -- is
+// then walked exactly once via a merge scan, producing every answer in one pass. The result is
+// in the same order as offsets.
+func (e *edgeFetcher) GetWatermarks(offsets []isb.Offset) []processor.Watermark {
+	if len(offsets) == 0 {
+		return nil
+	}
+
+	var sequences = make([]int64, len(offsets))
+	for i, o := range offsets {
+		var seq, err = o.Sequence()
+		if err != nil {
+			e.log.Errorw("unable to get offset from isb.Offset.Sequence()", zap.Error(err))
+			seq = -1
+		}
+		sequences[i] = seq
+	}
+
+	// sortedIdx[k] is the index into offsets/sequences of the k-th smallest sequence.
+	var sortedIdx = make([]int, len(sequences))
+	for i := range sortedIdx {
+		sortedIdx[i] = i
+	}
+	sort.Slice(sortedIdx, func(i, j int) bool { return sequences[sortedIdx[i]] < sequences[sortedIdx[j]] })
+
+	var sortedSeqs = make([]int64, len(sequences))
+	for k, idx := range sortedIdx {
+		sortedSeqs[k] = sequences[idx]
+	}
+
+	var epochs = make([]int64, len(offsets))
+	for i := range epochs {
+		epochs[i] = math.MaxInt64
+	}
+
+	var allProcessors = e.processorManager.GetAllProcessors()
+	for _, p := range allProcessors {
+		var times = p.offsetTimeline.GetEventTimesForSortedOffsets(sortedSeqs)
+		for k, t := range times {
+			var idx = sortedIdx[k]
+			if t != -1 && t < epochs[idx] {
+				epochs[idx] = t
+			}
+		}
+		if p.IsDeleted() {
+			if headOffset := p.offsetTimeline.GetHeadOffset(); len(sortedSeqs) > 0 && sortedSeqs[len(sortedSeqs)-1] > headOffset {
+				e.processorManager.DeleteProcessor(p.entity.GetID())
+			}
+		}
+	}
+
+	var watermarks = make([]processor.Watermark, len(offsets))
+	for i, epoch := range epochs {
+		if epoch == math.MaxInt64 {
+			epoch = -1
+		}
+		watermarks[i] = e.applyHolds(processor.Watermark(time.Unix(epoch, 0)))
+	}
+	return watermarks
+}
+
+// GetInputWatermark returns the minimum watermark currently known across every active upstream
+// processor's timeline, clamped by any outstanding holds. This is the watermark of data arriving
+// on the edge, before this vertex has done anything with it.
+func (e *edgeFetcher) GetInputWatermark() processor.Watermark {
+	var epoch int64 = math.MaxInt64
+	var allProcessors = e.processorManager.GetAllProcessors()
+	for _, p := range allProcessors {
+		if !p.IsActive() {
+			continue
+		}
+		var headOffset = p.offsetTimeline.GetHeadOffset()
+		if headOffset == -1 {
+			continue
+		}
+		var t = p.offsetTimeline.GetEventtimeFromInt64(headOffset)
+		if t != -1 && t < epoch {
+			epoch = t
+		}
+	}
+	if epoch == math.MaxInt64 {
+		return processor.Watermark(time.Unix(-1, 0))
+	}
+	return e.applyHolds(processor.Watermark(time.Unix(epoch, 0)))
+}
+
+// GetOutputWatermark returns GetInputWatermark further clamped by the oldest offset this vertex
+// currently has checked out for processing via CheckoutOffset, so a downstream edge never
+// observes a watermark more advanced than data this vertex has not finished with.
+func (e *edgeFetcher) GetOutputWatermark() processor.Watermark {
+	var wm = e.GetInputWatermark()
+	var oldestInFlight, ok = e.inProgress.min()
+	if !ok {
+		return wm
+	}
+
+	var epoch int64 = math.MaxInt64
+	var allProcessors = e.processorManager.GetAllProcessors()
+	for _, p := range allProcessors {
+		var t = p.offsetTimeline.GetEventtimeFromInt64(oldestInFlight)
+		if t != -1 && t < epoch {
+			epoch = t
+		}
+	}
+	if epoch == math.MaxInt64 {
+		// oldestInFlight couldn't be resolved against any processor's timeline, e.g. it predates
+		// every entry still retained after eviction. Treat that as "unknown" the same way
+		// GetWatermark/GetWatermarks/GetHeadWatermark do, rather than silently falling back to
+		// the unclamped input watermark -- the offset is still checked out, so the output
+		// watermark must not advance past it.
+		epoch = -1
+	}
+	var inFlightWM = e.applyHolds(processor.Watermark(time.Unix(epoch, 0)))
+	if inFlightWM.Before(wm) {
+		return inFlightWM
+	}
+	return wm
+}
+
+// CheckoutOffset marks offset as checked out for processing by this vertex.
+func (e *edgeFetcher) CheckoutOffset(offset isb.Offset) {
+	var seq, err = offset.Sequence()
+	if err != nil {
+		e.log.Errorw("unable to get offset from isb.Offset.Sequence()", zap.Error(err))
+		return
+	}
+	e.inProgress.checkout(seq)
+}
+
+// CommitOffset marks offset as no longer in-flight. See CheckoutOffset.
+func (e *edgeFetcher) CommitOffset(offset isb.Offset) {
+	var seq, err = offset.Sequence()
+	if err != nil {
+		e.log.Errorw("unable to get offset from isb.Offset.Sequence()", zap.Error(err))
+		return
+	}
+	e.inProgress.commit(seq)
+}
+
+// applyHolds clamps wm to the earliest outstanding watermark hold registered on
+// e.processorManager, if any. Holds can only ever move the returned watermark backward.
+func (e *edgeFetcher) applyHolds(wm processor.Watermark) processor.Watermark {
+	var minHold = e.processorManager.MinHold()
+	if minHold.IsZero() {
+		return wm
+	}
+	if minHold.Before(time.Time(wm)) {
+		return processor.Watermark(minHold)
+	}
+	return wm
 }
@@ -0,0 +1,83 @@
+package fetch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+func TestGetOutputWatermark_ClampsToOldestInFlightOffset(t *testing.T) {
+	var p1 = newTestProcessor("p1")
+	p1.offsetTimeline.Put(10, 100)
+	p1.offsetTimeline.Put(20, 200)
+	p1.offsetTimeline.Put(30, 300)
+
+	var e = newTestEdgeFetcher(p1)
+
+	var inputWM = e.GetInputWatermark()
+	if got := e.GetOutputWatermark(); got != inputWM {
+		t.Fatalf("GetOutputWatermark() with nothing in flight = %v, want %v", got, inputWM)
+	}
+
+	e.CheckoutOffset(testOffset{seq: 20})
+	var outputWM = e.GetOutputWatermark()
+	if !outputWM.Before(inputWM) {
+		t.Fatalf("GetOutputWatermark() = %v with offset 20 in flight, want earlier than input watermark %v", outputWM, inputWM)
+	}
+	if want := processor.Watermark(time.Unix(200, 0)); outputWM != want {
+		t.Fatalf("GetOutputWatermark() = %v, want %v", outputWM, want)
+	}
+
+	e.CommitOffset(testOffset{seq: 20})
+	if got := e.GetOutputWatermark(); got != inputWM {
+		t.Fatalf("GetOutputWatermark() after committing the only in-flight offset = %v, want %v", got, inputWM)
+	}
+}
+
+// TestGetOutputWatermark_UnresolvableInFlightOffsetBlocksWatermark reproduces checking out an
+// offset older than every entry a processor's timeline still retains after eviction.
+// GetOutputWatermark must treat that as "unknown" (the same -1 sentinel GetWatermark/
+// GetHeadWatermark/GetWatermarks use), not silently fall back to the unclamped input watermark --
+// the offset is still in flight, so the output watermark must not advance past it.
+func TestGetOutputWatermark_UnresolvableInFlightOffsetBlocksWatermark(t *testing.T) {
+	var p1 = newTestProcessor("p1")
+	p1.offsetTimeline = processor.NewOffsetTimeline(2)
+	p1.offsetTimeline.Put(10, 100)
+	p1.offsetTimeline.Put(20, 200)
+	p1.offsetTimeline.Put(30, 300) // evicts the entry for offset 10
+
+	var e = newTestEdgeFetcher(p1)
+
+	var inputWM = e.GetInputWatermark()
+	if inputWM == processor.Watermark(time.Unix(-1, 0)) {
+		t.Fatalf("GetInputWatermark() = %v, want a resolved watermark for this test to be meaningful", inputWM)
+	}
+
+	e.CheckoutOffset(testOffset{seq: 5}) // older than every retained entry
+
+	var want = processor.Watermark(time.Unix(-1, 0))
+	if got := e.GetOutputWatermark(); got != want {
+		t.Fatalf("GetOutputWatermark() with an unresolvable in-flight offset = %v, want %v (blocked)", got, want)
+	}
+}
+
+func TestGetOutputWatermark_ClampedByHold(t *testing.T) {
+	var p1 = newTestProcessor("p1")
+	p1.offsetTimeline.Put(10, 300)
+
+	var pm = NewProcessorManager(context.Background())
+	pm.processors["p1"] = p1
+	var e = NewEdgeFetcher(context.Background(), "test-edge", pm).(*edgeFetcher)
+
+	var holdTime = time.Unix(50, 0)
+	pm.AddProcessorHold("p1", "h1", holdTime)
+
+	if got := e.GetInputWatermark(); got != processor.Watermark(holdTime) {
+		t.Fatalf("GetInputWatermark() with an earlier hold registered = %v, want %v", got, holdTime)
+	}
+	if got := e.GetOutputWatermark(); got != processor.Watermark(holdTime) {
+		t.Fatalf("GetOutputWatermark() with an earlier hold registered = %v, want %v", got, holdTime)
+	}
+}
@@ -0,0 +1,69 @@
+package fetch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+// testOffset is a minimal isb.Offset for tests in this package.
+type testOffset struct{ seq int64 }
+
+func (o testOffset) String() string           { return "" }
+func (o testOffset) Sequence() (int64, error) { return o.seq, nil }
+func (o testOffset) AckIt() error             { return nil }
+
+func newTestEdgeFetcher(processors ...*ProcessorToFetch) *edgeFetcher {
+	var pm = NewProcessorManager(context.Background())
+	for _, p := range processors {
+		pm.processors[p.entity.GetID()] = p
+	}
+	return NewEdgeFetcher(context.Background(), "test-edge", pm).(*edgeFetcher)
+}
+
+// TestGetWatermarks_MatchesGetWatermarkPerOffset is the invariant the request calls out directly:
+// the batched merge-scan must produce the exact same answers as calling GetWatermark once per
+// offset, for offsets given in arbitrary order (including duplicates and offsets outside the
+// timeline).
+func TestGetWatermarks_MatchesGetWatermarkPerOffset(t *testing.T) {
+	var p1 = newTestProcessor("p1")
+	p1.offsetTimeline.Put(10, 100)
+	p1.offsetTimeline.Put(20, 200)
+	p1.offsetTimeline.Put(30, 300)
+
+	var p2 = newTestProcessor("p2")
+	p2.offsetTimeline.Put(5, 50)
+	p2.offsetTimeline.Put(25, 250)
+
+	var e = newTestEdgeFetcher(p1, p2)
+
+	var seqs = []int64{30, 1, 20, 20, 500, 15}
+	var offsets = make([]isb.Offset, len(seqs))
+	for i, s := range seqs {
+		offsets[i] = testOffset{seq: s}
+	}
+
+	var want = make([]processor.Watermark, len(offsets))
+	for i, o := range offsets {
+		want[i] = e.GetWatermark(o)
+	}
+
+	var got = e.GetWatermarks(offsets)
+	if len(got) != len(want) {
+		t.Fatalf("GetWatermarks returned %d results, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("GetWatermarks()[%d] (offset %d) = %v, want %v", i, seqs[i], got[i], want[i])
+		}
+	}
+}
+
+func TestGetWatermarks_Empty(t *testing.T) {
+	var e = newTestEdgeFetcher(newTestProcessor("p1"))
+	if got := e.GetWatermarks(nil); got != nil {
+		t.Fatalf("GetWatermarks(nil) = %v, want nil", got)
+	}
+}
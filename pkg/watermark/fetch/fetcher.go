@@ -0,0 +1,39 @@
+package fetch
+
+import (
+	"github.com/numaproj/numaflow/pkg/isb"
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+// Fetcher fetches watermarks for an edge, the connection between two vertices.
+type Fetcher interface {
+	// GetHeadWatermark returns the watermark at the head offset, the latest offset among all
+	// active processors writing to the edge, clamped by any outstanding holds. This can be used to show watermark progression for a vertex when not consuming messages
+	// directly (e.g. UX, tests).
+	GetHeadWatermark() processor.Watermark
+
+	// GetWatermark returns the watermark for the given offset.
+	GetWatermark(offset isb.Offset) processor.Watermark
+
+	// GetWatermarks returns the watermark for each of the given offsets in a single pass over
+	// the processor set, which is significantly cheaper than calling GetWatermark once per
+	// offset when fetching for a whole bundle at a time.
+	GetWatermarks(offsets []isb.Offset) []processor.Watermark
+
+	// GetInputWatermark returns the current watermark of the data arriving on this edge: the
+	// minimum watermark across all upstream processor timelines, clamped by any outstanding
+	// holds.
+	GetInputWatermark() processor.Watermark
+
+	// GetOutputWatermark returns GetInputWatermark further clamped by the oldest offset this
+	// vertex currently has checked out for processing (see CheckoutOffset), so that downstream
+	// edges never observe a watermark more advanced than data this vertex has not finished with.
+	GetOutputWatermark() processor.Watermark
+
+	// CheckoutOffset marks offset as checked out for processing by this vertex. The output
+	// watermark will not advance past it until CommitOffset is called.
+	CheckoutOffset(offset isb.Offset)
+
+	// CommitOffset marks offset as no longer in-flight. See CheckoutOffset.
+	CommitOffset(offset isb.Offset)
+}
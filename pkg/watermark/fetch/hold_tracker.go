@@ -0,0 +1,136 @@
+package fetch
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// holdEntry is a single entry in a holdHeap, ordered by holdTime and uniquely identified by id.
+// refCount allows the same id to be held more than once, e.g. by concurrent bundles that both
+// depend on the same late-pane timer.
+type holdEntry struct {
+	id       string
+	holdTime time.Time
+	refCount int
+	index    int // position in the heap, maintained by heap.Interface
+}
+
+// holdHeap is a min-heap of holdEntry ordered by holdTime.
+type holdHeap []*holdEntry
+
+func (h holdHeap) Len() int { return len(h) }
+
+func (h holdHeap) Less(i, j int) bool { return h[i].holdTime.Before(h[j].holdTime) }
+
+func (h holdHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *holdHeap) Push(x any) {
+	var e = x.(*holdEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *holdHeap) Pop() any {
+	var old = *h
+	var n = len(old)
+	var e = old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
+// HoldTracker tracks watermark holds: requests from downstream components (timers, async sinks,
+// windowing operators waiting on late panes, bundle-scoped UDF side effects) to prevent an edge's
+// watermark from advancing past a given event time until the hold is released. A HoldTracker can
+// only ever push the effective watermark backward; MinHold has no effect on an edge with no
+// outstanding holds.
+//
+// Holds are keyed by a caller-chosen id. The same id can be added more than once, in which case
+// HoldTracker ref-counts it so DecrementHold only clears the hold once every AddHold/IncrementHold
+// has a matching DecrementHold. ReleaseHold clears the hold at once, regardless of its ref count.
+// Removal by id is O(log n) via an index into the heap.
+type HoldTracker struct {
+	lock    sync.Mutex
+	entries holdHeap
+	index   map[string]*holdEntry
+}
+
+// NewHoldTracker returns an empty HoldTracker.
+func NewHoldTracker() *HoldTracker {
+	return &HoldTracker{
+		entries: make(holdHeap, 0),
+		index:   make(map[string]*holdEntry),
+	}
+}
+
+// AddHold registers a new hold for id at t, or increments its ref count if id is already held.
+// AddHold does not move an existing hold's time; release and re-add to change it.
+func (h *HoldTracker) AddHold(id string, t time.Time) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if e, ok := h.index[id]; ok {
+		e.refCount++
+		return
+	}
+	var e = &holdEntry{id: id, holdTime: t, refCount: 1}
+	h.index[id] = e
+	heap.Push(&h.entries, e)
+}
+
+// IncrementHold increments the ref count of an existing hold. It is a no-op if id is not held.
+func (h *HoldTracker) IncrementHold(id string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if e, ok := h.index[id]; ok {
+		e.refCount++
+	}
+}
+
+// DecrementHold decrements the ref count of an existing hold, removing it once the count reaches
+// zero. It is a no-op if id is not held.
+func (h *HoldTracker) DecrementHold(id string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	var e, ok = h.index[id]
+	if !ok {
+		return
+	}
+	e.refCount--
+	if e.refCount <= 0 {
+		h.removeLocked(e)
+	}
+}
+
+// ReleaseHold removes a hold for id regardless of its ref count. Releasing a hold that does not
+// exist is a no-op.
+func (h *HoldTracker) ReleaseHold(id string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	var e, ok = h.index[id]
+	if !ok {
+		return
+	}
+	h.removeLocked(e)
+}
+
+// removeLocked removes e from the heap and index. Callers must hold h.lock.
+func (h *HoldTracker) removeLocked(e *holdEntry) {
+	heap.Remove(&h.entries, e.index)
+	delete(h.index, e.id)
+}
+
+// MinHold returns the earliest active hold time, or the zero time.Time if there are no holds.
+func (h *HoldTracker) MinHold() time.Time {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if len(h.entries) == 0 {
+		return time.Time{}
+	}
+	return h.entries[0].holdTime
+}
@@ -0,0 +1,133 @@
+package fetch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHoldTracker_MinHold_Empty(t *testing.T) {
+	var h = NewHoldTracker()
+	if got := h.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() on empty tracker = %v, want zero time", got)
+	}
+}
+
+func TestHoldTracker_MinHold_TracksEarliest(t *testing.T) {
+	var h = NewHoldTracker()
+	var t1 = time.Unix(100, 0)
+	var t2 = time.Unix(50, 0)
+	var t3 = time.Unix(200, 0)
+
+	h.AddHold("a", t1)
+	h.AddHold("b", t2)
+	h.AddHold("c", t3)
+
+	if got := h.MinHold(); !got.Equal(t2) {
+		t.Fatalf("MinHold() = %v, want %v", got, t2)
+	}
+
+	h.ReleaseHold("b")
+	if got := h.MinHold(); !got.Equal(t1) {
+		t.Fatalf("MinHold() after releasing earliest = %v, want %v", got, t1)
+	}
+}
+
+// TestHoldTracker_RefCounting is the exact AddHold/AddHold/DecrementHold/MinHold scenario the
+// request called out: a hold registered twice for the same id must survive a single decrement.
+// ReleaseHold is deliberately not used here -- it force-clears regardless of ref count (see
+// TestHoldTracker_ReleaseHold_ForceClearsRegardlessOfRefCount below); DecrementHold is the
+// ref-counted release, exactly as ProcessorManager.ReleaseProcessorHold uses it.
+func TestHoldTracker_RefCounting(t *testing.T) {
+	var h = NewHoldTracker()
+	var holdTime = time.Unix(100, 0)
+
+	h.AddHold("a", holdTime)
+	h.AddHold("a", holdTime)
+
+	h.DecrementHold("a")
+	if got := h.MinHold(); got.IsZero() {
+		t.Fatalf("MinHold() = zero after single DecrementHold on a doubly-held id, want %v", holdTime)
+	}
+
+	h.DecrementHold("a")
+	if got := h.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after releasing every reference, want zero time", got)
+	}
+}
+
+func TestHoldTracker_IncrementDecrementHold(t *testing.T) {
+	var h = NewHoldTracker()
+	var holdTime = time.Unix(100, 0)
+	h.AddHold("a", holdTime)
+	h.IncrementHold("a")
+
+	h.DecrementHold("a")
+	if got := h.MinHold(); got.IsZero() {
+		t.Fatalf("MinHold() = zero after one of two references decremented, want %v", holdTime)
+	}
+
+	h.DecrementHold("a")
+	if got := h.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after last reference decremented, want zero time", got)
+	}
+}
+
+func TestHoldTracker_IncrementDecrementHold_UnknownIDIsNoop(t *testing.T) {
+	var h = NewHoldTracker()
+	h.IncrementHold("missing")
+	h.DecrementHold("missing")
+	if got := h.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v, want zero time", got)
+	}
+}
+
+func TestHoldTracker_ReleaseHold_ForceClearsRegardlessOfRefCount(t *testing.T) {
+	var h = NewHoldTracker()
+	h.AddHold("a", time.Unix(100, 0))
+	h.AddHold("a", time.Unix(100, 0))
+
+	h.ReleaseHold("a")
+	if got := h.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after ReleaseHold on a doubly-held id, want zero time", got)
+	}
+}
+
+func TestHoldTracker_ReleaseHold_UnknownIDIsNoop(t *testing.T) {
+	var h = NewHoldTracker()
+	h.AddHold("a", time.Unix(100, 0))
+	h.ReleaseHold("does-not-exist")
+	if got := h.MinHold(); got.IsZero() {
+		t.Fatalf("MinHold() = zero after releasing an unrelated id, want the still-held hold")
+	}
+}
+
+// TestHoldTracker_RemovalByIDIsHeapConsistent exercises removing an arbitrary interior heap
+// entry by id (not just the current minimum), which is what the index map exists for.
+func TestHoldTracker_RemovalByIDIsHeapConsistent(t *testing.T) {
+	var h = NewHoldTracker()
+	var times = map[string]time.Time{
+		"a": time.Unix(10, 0),
+		"b": time.Unix(20, 0),
+		"c": time.Unix(30, 0),
+		"d": time.Unix(40, 0),
+		"e": time.Unix(50, 0),
+	}
+	for id, tm := range times {
+		h.AddHold(id, tm)
+	}
+
+	h.ReleaseHold("c")
+	delete(times, "c")
+
+	var wantMin = time.Unix(10, 0)
+	if got := h.MinHold(); !got.Equal(wantMin) {
+		t.Fatalf("MinHold() = %v, want %v", got, wantMin)
+	}
+
+	for id := range times {
+		h.ReleaseHold(id)
+	}
+	if got := h.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after releasing every hold, want zero time", got)
+	}
+}
@@ -0,0 +1,76 @@
+package fetch
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// offsetHeap is a min-heap of in-progress offsets.
+type offsetHeap []int64
+
+func (h offsetHeap) Len() int           { return len(h) }
+func (h offsetHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h offsetHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *offsetHeap) Push(x any) { *h = append(*h, x.(int64)) }
+
+func (h *offsetHeap) Pop() any {
+	var old = *h
+	var n = len(old)
+	var x = old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// offsetTracker tracks the set of offsets a vertex currently has checked out for processing, so
+// the output watermark can be clamped to the oldest offset still in flight. Checking out the same
+// offset more than once (e.g. a bundle retry) is ref-counted so one commit cannot release an
+// offset another in-flight bundle still depends on.
+type offsetTracker struct {
+	lock   sync.Mutex
+	counts map[int64]int
+	heap   offsetHeap
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{counts: make(map[int64]int)}
+}
+
+// checkout marks offset as in-flight.
+func (t *offsetTracker) checkout(offset int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.counts[offset] == 0 {
+		heap.Push(&t.heap, offset)
+	}
+	t.counts[offset]++
+}
+
+// commit marks offset as no longer in-flight. Committing an offset that was never checked out is
+// a no-op.
+func (t *offsetTracker) commit(offset int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.counts[offset] == 0 {
+		return
+	}
+	t.counts[offset]--
+	if t.counts[offset] == 0 {
+		delete(t.counts, offset)
+	}
+}
+
+// min returns the smallest in-flight offset and true, or (0, false) if nothing is in flight.
+// Heap entries that have since been fully committed are discarded lazily.
+func (t *offsetTracker) min() (int64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for len(t.heap) > 0 {
+		var head = t.heap[0]
+		if t.counts[head] > 0 {
+			return head, true
+		}
+		heap.Pop(&t.heap)
+	}
+	return 0, false
+}
@@ -0,0 +1,69 @@
+package fetch
+
+import "testing"
+
+func TestOffsetTracker_Min_Empty(t *testing.T) {
+	var tr = newOffsetTracker()
+	if _, ok := tr.min(); ok {
+		t.Fatalf("min() on empty tracker returned ok=true, want false")
+	}
+}
+
+func TestOffsetTracker_Min_TracksOldest(t *testing.T) {
+	var tr = newOffsetTracker()
+	tr.checkout(10)
+	tr.checkout(5)
+	tr.checkout(20)
+
+	if got, ok := tr.min(); !ok || got != 5 {
+		t.Fatalf("min() = (%d, %v), want (5, true)", got, ok)
+	}
+
+	tr.commit(5)
+	if got, ok := tr.min(); !ok || got != 10 {
+		t.Fatalf("min() after committing the oldest = (%d, %v), want (10, true)", got, ok)
+	}
+}
+
+// TestOffsetTracker_RefCounting mirrors a bundle retry checking out the same offset twice: one
+// commit must not release an offset another in-flight checkout still depends on.
+func TestOffsetTracker_RefCounting(t *testing.T) {
+	var tr = newOffsetTracker()
+	tr.checkout(5)
+	tr.checkout(5)
+
+	tr.commit(5)
+	if got, ok := tr.min(); !ok || got != 5 {
+		t.Fatalf("min() = (%d, %v) after single commit of a doubly-checked-out offset, want (5, true)", got, ok)
+	}
+
+	tr.commit(5)
+	if _, ok := tr.min(); ok {
+		t.Fatalf("min() returned ok=true after every checkout was committed, want false")
+	}
+}
+
+func TestOffsetTracker_Commit_NeverCheckedOutIsNoop(t *testing.T) {
+	var tr = newOffsetTracker()
+	tr.checkout(5)
+	tr.commit(99)
+	if got, ok := tr.min(); !ok || got != 5 {
+		t.Fatalf("min() = (%d, %v) after committing an offset never checked out, want (5, true)", got, ok)
+	}
+}
+
+// TestOffsetTracker_Min_LazilyDiscardsCommittedHeapEntries checks that stale heap entries left
+// behind by commit are skipped over rather than reported as still in flight.
+func TestOffsetTracker_Min_LazilyDiscardsCommittedHeapEntries(t *testing.T) {
+	var tr = newOffsetTracker()
+	tr.checkout(1)
+	tr.checkout(2)
+	tr.checkout(3)
+
+	tr.commit(1)
+	tr.commit(2)
+
+	if got, ok := tr.min(); !ok || got != 3 {
+		t.Fatalf("min() = (%d, %v), want (3, true)", got, ok)
+	}
+}
@@ -0,0 +1,183 @@
+package fetch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/numaproj/numaflow/pkg/shared/logging"
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+// processorStatus is the lifecycle state of a ProcessorToFetch.
+type processorStatus int
+
+const (
+	_ processorStatus = iota
+	active
+	deleted
+)
+
+func (s processorStatus) String() string {
+	switch s {
+	case active:
+		return "active"
+	case deleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// ProcessorToFetch is the smallest unit (from the fetcher's point of view) that writes
+// watermarks for a partition of an edge.
+type ProcessorToFetch struct {
+	entity         processor.ProcessorEntitier
+	offsetTimeline *processor.OffsetTimeline
+	status         processorStatus
+	lock           sync.RWMutex
+}
+
+// IsActive returns true if the processor is still writing to the edge.
+func (p *ProcessorToFetch) IsActive() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.status == active
+}
+
+// IsDeleted returns true if the processor has been removed from the ProcessorManager.
+func (p *ProcessorToFetch) IsDeleted() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.status == deleted
+}
+
+func (p *ProcessorToFetch) setDeleted() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.status = deleted
+}
+
+func (p *ProcessorToFetch) String() string {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return fmt.Sprintf("processor:%s (status:%s)", p.entity.GetID(), p.status)
+}
+
+// ProcessorManager keeps track of the set of processors (upstream partitions) writing to an edge,
+// along with any watermark holds registered against those processors.
+type ProcessorManager struct {
+	ctx        context.Context
+	processors map[string]*ProcessorToFetch
+	lock       sync.RWMutex
+
+	// holds tracks watermark holds registered via AddProcessorHold, namespaced by processor so
+	// that a deleted processor's holds are always cleaned up and can never block the watermark
+	// forever. holdsByProc is guarded by lock (not a separate mutex) so that a processor's
+	// liveness check, its hold registration, and its deletion cleanup are always atomic with
+	// respect to one another.
+	holds       *HoldTracker
+	holdsByProc map[string]map[string]int
+
+	log *zap.SugaredLogger
+}
+
+// NewProcessorManager returns an empty ProcessorManager for the given context.
+func NewProcessorManager(ctx context.Context) *ProcessorManager {
+	return &ProcessorManager{
+		ctx:         ctx,
+		processors:  make(map[string]*ProcessorToFetch),
+		holds:       NewHoldTracker(),
+		holdsByProc: make(map[string]map[string]int),
+		log:         logging.FromContext(ctx),
+	}
+}
+
+// GetAllProcessors returns a snapshot of every processor known to the manager, keyed by entity ID.
+func (pm *ProcessorManager) GetAllProcessors() map[string]*ProcessorToFetch {
+	pm.lock.RLock()
+	defer pm.lock.RUnlock()
+	var copied = make(map[string]*ProcessorToFetch, len(pm.processors))
+	for k, v := range pm.processors {
+		copied[k] = v
+	}
+	return copied
+}
+
+// DeleteProcessor marks the processor with the given id as deleted and removes it from the
+// manager, releasing any watermark holds still registered against it.
+func (pm *ProcessorManager) DeleteProcessor(id string) {
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	if p, ok := pm.processors[id]; ok {
+		p.setDeleted()
+		delete(pm.processors, id)
+	}
+	pm.releaseProcessorHoldsLocked(id)
+}
+
+// AddProcessorHold registers a watermark hold keyed by holdID against the given processor, so
+// that an in-flight bundle, timer, or side effect owned by that processor can prevent the edge's
+// watermark from advancing past t. Holds registered this way are automatically released when the
+// owning processor is deleted, so a dead pod can never hold the watermark back indefinitely. If
+// processorID is not (or no longer) a live processor, AddProcessorHold is a no-op, since there is
+// no future DeleteProcessor call left to ever release the hold.
+func (pm *ProcessorManager) AddProcessorHold(processorID, holdID string, t time.Time) {
+	var key = processorHoldKey(processorID, holdID)
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	if _, ok := pm.processors[processorID]; !ok {
+		return
+	}
+	if pm.holdsByProc[processorID] == nil {
+		pm.holdsByProc[processorID] = make(map[string]int)
+	}
+	pm.holdsByProc[processorID][key]++
+	pm.holds.AddHold(key, t)
+}
+
+// ReleaseProcessorHold releases a single hold previously registered with AddProcessorHold. Same
+// as HoldTracker itself, repeated holds on the same (processorID, holdID) are ref-counted, so
+// ReleaseProcessorHold only clears the underlying hold once every AddProcessorHold call has a
+// matching release. Releasing a hold that does not exist is a no-op.
+func (pm *ProcessorManager) ReleaseProcessorHold(processorID, holdID string) {
+	var key = processorHoldKey(processorID, holdID)
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+	if holds, ok := pm.holdsByProc[processorID]; ok {
+		if holds[key] > 0 {
+			holds[key]--
+		}
+		if holds[key] == 0 {
+			delete(holds, key)
+		}
+		if len(holds) == 0 {
+			delete(pm.holdsByProc, processorID)
+		}
+	}
+	pm.holds.DecrementHold(key)
+}
+
+// releaseProcessorHoldsLocked force-clears every hold still registered for processorID,
+// regardless of ref count, since the processor owning them is gone for good. Callers must hold
+// pm.lock.
+func (pm *ProcessorManager) releaseProcessorHoldsLocked(processorID string) {
+	var holds = pm.holdsByProc[processorID]
+	delete(pm.holdsByProc, processorID)
+	for key := range holds {
+		pm.holds.ReleaseHold(key)
+	}
+}
+
+// MinHold returns the earliest watermark hold registered across all processors managed by pm, or
+// the zero time.Time if there are no outstanding holds.
+func (pm *ProcessorManager) MinHold() time.Time {
+	return pm.holds.MinHold()
+}
+
+func processorHoldKey(processorID, holdID string) string {
+	return processorID + "/" + holdID
+}
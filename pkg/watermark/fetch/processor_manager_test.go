@@ -0,0 +1,96 @@
+package fetch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/numaproj/numaflow/pkg/watermark/processor"
+)
+
+// fakeEntity is a minimal processor.ProcessorEntitier for tests in this package.
+type fakeEntity struct{ id string }
+
+func (f fakeEntity) GetID() string { return f.id }
+
+func newTestProcessor(id string) *ProcessorToFetch {
+	return &ProcessorToFetch{
+		entity:         fakeEntity{id: id},
+		offsetTimeline: processor.NewOffsetTimeline(10),
+		status:         active,
+	}
+}
+
+func TestProcessorManager_AddProcessorHold_UnknownProcessorIsNoop(t *testing.T) {
+	var pm = NewProcessorManager(context.Background())
+	pm.AddProcessorHold("does-not-exist", "h1", time.Unix(100, 0))
+	if got := pm.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after holding against an unknown processor, want zero time", got)
+	}
+}
+
+// TestProcessorManager_ReleaseProcessorHold_RefCounts is the exact scenario the request calls
+// out: two holders registering the same (processorID, holdID) must both release before the
+// watermark hold clears.
+func TestProcessorManager_ReleaseProcessorHold_RefCounts(t *testing.T) {
+	var pm = NewProcessorManager(context.Background())
+	pm.processors["p1"] = newTestProcessor("p1")
+
+	var holdTime = time.Unix(100, 0)
+	pm.AddProcessorHold("p1", "h1", holdTime)
+	pm.AddProcessorHold("p1", "h1", holdTime)
+
+	pm.ReleaseProcessorHold("p1", "h1")
+	if got := pm.MinHold(); got.IsZero() {
+		t.Fatalf("MinHold() = zero after one of two holders released, want %v", holdTime)
+	}
+
+	pm.ReleaseProcessorHold("p1", "h1")
+	if got := pm.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after both holders released, want zero time", got)
+	}
+}
+
+func TestProcessorManager_ReleaseProcessorHold_UnknownIsNoop(t *testing.T) {
+	var pm = NewProcessorManager(context.Background())
+	pm.processors["p1"] = newTestProcessor("p1")
+	pm.AddProcessorHold("p1", "h1", time.Unix(100, 0))
+
+	pm.ReleaseProcessorHold("p1", "does-not-exist")
+	if got := pm.MinHold(); got.IsZero() {
+		t.Fatalf("MinHold() = zero after releasing an unrelated hold, want the still-held hold")
+	}
+}
+
+// TestProcessorManager_DeleteProcessor_ForceClearsOutstandingHolds asserts that deleting a
+// processor releases its holds unconditionally, even if a holder never released its reference
+// (a dead pod can never hold the watermark back indefinitely).
+func TestProcessorManager_DeleteProcessor_ForceClearsOutstandingHolds(t *testing.T) {
+	var pm = NewProcessorManager(context.Background())
+	pm.processors["p1"] = newTestProcessor("p1")
+
+	pm.AddProcessorHold("p1", "h1", time.Unix(100, 0))
+	pm.AddProcessorHold("p1", "h1", time.Unix(100, 0))
+
+	pm.DeleteProcessor("p1")
+	if got := pm.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after deleting the owning processor, want zero time", got)
+	}
+}
+
+// TestProcessorManager_AddProcessorHold_AfterDeleteIsNoop guards against the race where a hold
+// is registered against a processor that is already gone, leaving no future DeleteProcessor call
+// to ever release it.
+func TestProcessorManager_AddProcessorHold_AfterDeleteIsNoop(t *testing.T) {
+	var pm = NewProcessorManager(context.Background())
+	pm.processors["p1"] = newTestProcessor("p1")
+	pm.DeleteProcessor("p1")
+
+	pm.AddProcessorHold("p1", "h1", time.Unix(100, 0))
+	if got := pm.MinHold(); !got.IsZero() {
+		t.Fatalf("MinHold() = %v after holding against a deleted processor, want zero time", got)
+	}
+	if holds, ok := pm.holdsByProc["p1"]; ok && len(holds) > 0 {
+		t.Fatalf("holdsByProc[\"p1\"] = %v after holding against a deleted processor, want empty", holds)
+	}
+}
@@ -0,0 +1,8 @@
+package processor
+
+// ProcessorEntitier identifies the owner of a partition of watermark state that an edge's
+// fetcher tracks, typically a single pod of the upstream vertex.
+type ProcessorEntitier interface {
+	// GetID returns the unique identifier of the entity.
+	GetID() string
+}
@@ -0,0 +1,102 @@
+package processor
+
+import (
+	"sync"
+
+	"github.com/numaproj/numaflow/pkg/isb"
+)
+
+// timelineEntry associates an offset with the event time that was the watermark at the time the
+// entry was appended.
+type timelineEntry struct {
+	offset    int64
+	eventtime int64
+}
+
+// OffsetTimeline is an append-only log of (offset, eventtime) pairs reported by a single
+// processor. Entries are always appended in strictly increasing offset order, so the timeline is
+// monotonic and can be searched without an ordered map.
+type OffsetTimeline struct {
+	lock     sync.RWMutex
+	entries  []timelineEntry
+	capacity int
+}
+
+// NewOffsetTimeline returns an empty OffsetTimeline that retains at most capacity entries.
+func NewOffsetTimeline(capacity int) *OffsetTimeline {
+	return &OffsetTimeline{
+		entries:  make([]timelineEntry, 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// Put appends a new (offset, eventtime) entry, evicting the oldest entry once the timeline is at
+// capacity.
+func (t *OffsetTimeline) Put(offset, eventtime int64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.capacity > 0 && len(t.entries) >= t.capacity {
+		t.entries = t.entries[1:]
+	}
+	t.entries = append(t.entries, timelineEntry{offset: offset, eventtime: eventtime})
+}
+
+// GetHeadOffset returns the most recently appended offset, or -1 if the timeline is empty.
+func (t *OffsetTimeline) GetHeadOffset() int64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	if len(t.entries) == 0 {
+		return -1
+	}
+	return t.entries[len(t.entries)-1].offset
+}
+
+// GetEventtimeFromInt64 returns the eventtime recorded for offset, or -1 if offset predates every
+// entry in the timeline.
+func (t *OffsetTimeline) GetEventtimeFromInt64(offset int64) int64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.getEventtimeLocked(offset)
+}
+
+func (t *OffsetTimeline) getEventtimeLocked(offset int64) int64 {
+	// entries are offset-sorted; walk back from the head since lookups are almost always for a
+	// recent offset.
+	for i := len(t.entries) - 1; i >= 0; i-- {
+		if t.entries[i].offset <= offset {
+			return t.entries[i].eventtime
+		}
+	}
+	return -1
+}
+
+// GetEventTime returns the eventtime recorded for the offset encoded in o, or -1 if o cannot be
+// resolved or predates every entry in the timeline.
+func (t *OffsetTimeline) GetEventTime(o isb.Offset) int64 {
+	seq, err := o.Sequence()
+	if err != nil {
+		return -1
+	}
+	return t.GetEventtimeFromInt64(seq)
+}
+
+// GetEventTimesForSortedOffsets resolves the eventtime for each offset in sortedOffsets, which
+// MUST already be sorted ascending, in a single left-to-right walk over the timeline rather than
+// the O(len(sortedOffsets) * len(entries)) cost of calling GetEventtimeFromInt64 once per offset.
+// Both the timeline and sortedOffsets are monotonic, so this is a plain merge scan.
+func (t *OffsetTimeline) GetEventTimesForSortedOffsets(sortedOffsets []int64) []int64 {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	var results = make([]int64, len(sortedOffsets))
+	var entryIdx = 0
+	var lastEventtime int64 = -1
+	for i, offset := range sortedOffsets {
+		for entryIdx < len(t.entries) && t.entries[entryIdx].offset <= offset {
+			lastEventtime = t.entries[entryIdx].eventtime
+			entryIdx++
+		}
+		results[i] = lastEventtime
+	}
+	return results
+}
@@ -0,0 +1,22 @@
+package processor
+
+import "time"
+
+// Watermark is the monotonically increasing event time below which a vertex has seen (or claims
+// to have seen) all the data. It wraps time.Time purely so watermark-producing APIs return a
+// type that cannot be confused with an arbitrary timestamp.
+type Watermark time.Time
+
+func (w Watermark) String() string {
+	return time.Time(w).String()
+}
+
+// Before reports whether w occurred before t.
+func (w Watermark) Before(t Watermark) bool {
+	return time.Time(w).Before(time.Time(t))
+}
+
+// After reports whether w occurred after t.
+func (w Watermark) After(t Watermark) bool {
+	return time.Time(w).After(time.Time(t))
+}